@@ -0,0 +1,35 @@
+package spotify_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmcvetta/napping"
+)
+
+type albumsEnvelope struct {
+	Albums []Album `json:"albums"`
+}
+
+// GetAlbum retrieves catalog information for a single album.
+func (c *Client) GetAlbum(id string, opts *Options) (*Album, error) {
+	reqUrl := appendOptions(fmt.Sprintf("%v/albums/%v", c.baseURL, id), opts)
+	s := napping.Session{Client: c.http}
+	album := new(Album)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: album}); err != nil {
+		return nil, err
+	}
+	return album, nil
+}
+
+// GetAlbums retrieves catalog information for multiple albums in a single
+// request. Spotify allows up to 20 ids per call.
+func (c *Client) GetAlbums(ids []string, opts *Options) ([]Album, error) {
+	reqUrl := fmt.Sprintf("%v/albums?ids=%v%v", c.baseURL, strings.Join(ids, ","), optionsQuery(opts))
+	s := napping.Session{Client: c.http}
+	res := new(albumsEnvelope)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res.Albums, nil
+}
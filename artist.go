@@ -0,0 +1,45 @@
+package spotify_client
+
+import (
+	"fmt"
+
+	"github.com/jmcvetta/napping"
+)
+
+type artistsEnvelope struct {
+	Artists []Artist `json:"artists"`
+}
+
+// GetArtist retrieves catalog information for a single artist.
+func (c *Client) GetArtist(id string) (*Artist, error) {
+	reqUrl := fmt.Sprintf("%v/artists/%v", c.baseURL, id)
+	s := napping.Session{Client: c.http}
+	artist := new(Artist)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: artist}); err != nil {
+		return nil, err
+	}
+	return artist, nil
+}
+
+// GetArtistTopTracks retrieves an artist's top tracks in the given market.
+func (c *Client) GetArtistTopTracks(id string, market string) ([]Track, error) {
+	reqUrl := fmt.Sprintf("%v/artists/%v/top-tracks?market=%v", c.baseURL, id, market)
+	s := napping.Session{Client: c.http}
+	res := new(tracksEnvelope)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res.Tracks, nil
+}
+
+// GetRelatedArtists retrieves artists similar to the given artist, based on
+// listening history.
+func (c *Client) GetRelatedArtists(id string) ([]Artist, error) {
+	reqUrl := fmt.Sprintf("%v/artists/%v/related-artists", c.baseURL, id)
+	s := napping.Session{Client: c.http}
+	res := new(artistsEnvelope)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res.Artists, nil
+}
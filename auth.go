@@ -0,0 +1,66 @@
+package spotify_client
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	spotifyoauth2 "golang.org/x/oauth2/spotify"
+)
+
+// An Authenticator drives the OAuth2 authorization code flow ("3-legged
+// auth") used to act on behalf of a Spotify user. Build one with
+// NewAuthenticator, send the user to AuthCodeURL, then call Exchange with
+// the "code" query parameter Spotify appends to the redirect URI.
+//
+// The *http.Client returned by Exchange and Client automatically refreshes
+// the access token using the stored refresh token once it expires, so
+// callers no longer need to re-run the authorization flow every hour.
+type Authenticator struct {
+	config *oauth2.Config
+}
+
+// NewAuthenticator builds an Authenticator for the given Spotify app
+// credentials, redirect URI, and OAuth2 scopes.
+func NewAuthenticator(clientId ClientId, clientSecret ClientSecret, redirectUri RedirectUri, scopes ...string) *Authenticator {
+	return &Authenticator{
+		config: &oauth2.Config{
+			ClientID:     string(clientId),
+			ClientSecret: string(clientSecret),
+			RedirectURL:  string(redirectUri),
+			Scopes:       scopes,
+			Endpoint:     spotifyoauth2.Endpoint,
+		},
+	}
+}
+
+// AuthCodeURL returns the URL to redirect the end user to in order to
+// request their approval. state is echoed back by Spotify on the redirect
+// and should be validated by the caller to protect against CSRF.
+func (a *Authenticator) AuthCodeURL(state string) string {
+	return a.config.AuthCodeURL(state)
+}
+
+// Exchange trades an authorization code for an oauth2.Token and wraps it in
+// an *http.Client suitable for use with the functions in this package.
+func (a *Authenticator) Exchange(ctx context.Context, code string) (*http.Client, *oauth2.Token, error) {
+	token, err := a.config.Exchange(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a.Client(ctx, token), token, nil
+}
+
+// Client returns an *http.Client that authenticates requests using token,
+// automatically refreshing it via the token source when it expires.
+func (a *Authenticator) Client(ctx context.Context, token *oauth2.Token) *http.Client {
+	return a.config.Client(ctx, token)
+}
+
+// TokenSource returns an oauth2.TokenSource that refreshes token as needed.
+// Callers that want to persist refreshed tokens between restarts should
+// wrap the returned TokenSource and save whatever new token it produces
+// after each call to Token().
+func (a *Authenticator) TokenSource(ctx context.Context, token *oauth2.Token) oauth2.TokenSource {
+	return a.config.TokenSource(ctx, token)
+}
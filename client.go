@@ -0,0 +1,69 @@
+package spotify_client
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// baseURL is the root of the Spotify Web API.
+const baseURL = "https://api.spotify.com/v1"
+
+// A Client wraps an authenticated *http.Client and knows how to talk to the
+// Spotify Web API. Unlike the package-level functions in lib.go - which
+// predate this type and are kept for backward compatibility - Client groups
+// the full catalog/search/player surface as methods. Rate-limit handling
+// (see doRequest) applies per call, not across calls sharing a Client; two
+// concurrent calls that both hit a 429 will each sleep and retry
+// independently.
+//
+// Build a Client with NewAppClient for app-only access to public data, or by
+// wrapping the *http.Client returned by an Authenticator for calls that act
+// on behalf of a user.
+type Client struct {
+	http    *http.Client
+	baseURL string
+}
+
+// NewClient wraps an already-authenticated *http.Client - such as one
+// returned by Authenticator.Client or Authenticator.Exchange - in a Client.
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{http: httpClient, baseURL: baseURL}
+}
+
+// NewAppClient builds a Client authenticated via the OAuth2 client
+// credentials grant, exchanging clientId and clientSecret for an app-only
+// bearer token. Unlike an Authenticator-based Client, this does not require
+// end-user consent, so it's only usable against endpoints that serve public
+// catalog data (search, albums, artists, public playlists, and so on).
+//
+// The returned Client caches its token and refreshes it automatically once
+// expires_in elapses, so it's safe to reuse for the lifetime of a long-running
+// process such as a bot or batch script.
+func NewAppClient(clientId ClientId, clientSecret ClientSecret) (*Client, error) {
+	config := &clientcredentials.Config{
+		ClientID:     string(clientId),
+		ClientSecret: string(clientSecret),
+		TokenURL:     "https://accounts.spotify.com/api/token",
+	}
+	ctx := context.Background()
+	if _, err := config.Token(ctx); err != nil {
+		return nil, err
+	}
+	return &Client{http: config.Client(ctx), baseURL: baseURL}, nil
+}
+
+// Options carries the optional parameters accepted by most catalog
+// endpoints. A nil *Options is equivalent to an empty one.
+type Options struct {
+	// Market is an ISO 3166-1 alpha-2 country code used to filter results
+	// to content available in that market.
+	Market string
+	// Limit is the maximum number of items to return. Spotify defaults and
+	// caps vary by endpoint; a zero value leaves it unset, except for the
+	// iterators, which then request their own default page size.
+	Limit int32
+	// Offset is the index of the first item to return, for paging.
+	Offset int32
+}
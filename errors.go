@@ -0,0 +1,22 @@
+package spotify_client
+
+import "fmt"
+
+// An APIError is returned when the Spotify Web API responds to a request
+// with its standard {"error": {"status": ..., "message": ...}} envelope, as
+// it does for most non-2xx responses. Callers can inspect Status to
+// distinguish authentication failures (401) and permission errors (403)
+// from rate limiting (429) or other transport-level failures.
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("spotify: %v %v", e.Status, e.Message)
+}
+
+// apiErrorEnvelope mirrors the shape Spotify wraps APIError in.
+type apiErrorEnvelope struct {
+	Err *APIError `json:"error"`
+}
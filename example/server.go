@@ -11,7 +11,6 @@ import (
 	s "github.com/jbuberel/spotify_client"
 	"log"
 	"net/http"
-	"net/url"
 	"os"
 	"strings"
 )
@@ -36,6 +35,19 @@ var ClientId s.ClientId = ""
 var ClientSecret s.ClientSecret = ""
 var RedirectUri s.RedirectUri = "http://localhost:8080/callback/"
 
+// authenticator drives the OAuth2 authorization code flow. It is built in
+// init(), once ClientId and ClientSecret have been read from the
+// environment, and reused for the lifetime of the process - its returned
+// *http.Client instances refresh themselves automatically, so there's no
+// need to re-authenticate every hour.
+var authenticator *s.Authenticator
+
+// clients holds the authenticated *http.Client for each user that has
+// completed the login flow, keyed by username. A real application would
+// persist these (or at least their refresh tokens) somewhere durable
+// instead of keeping them in memory.
+var clients = map[s.Username]*http.Client{}
+
 // The init function will look through environment variables
 // to find the client_id and client_secret, which need to come from your
 // Spotify Developer Applications settings - see https://developer.spotify.com/my-applications/
@@ -56,6 +68,9 @@ func init() {
 			}
 		}
 	}
+
+	authenticator = s.NewAuthenticator(ClientId, ClientSecret, RedirectUri,
+		"playlist-read-private", "playlist-modify-private", "user-read-private")
 }
 
 // This function is required to start the end-user visible
@@ -68,10 +83,7 @@ func init() {
 // that you configured in your Spotify Application - see
 // https://developer.spotify.com/my-applications/ for more information.
 func sendLogion(w http.ResponseWriter, r *http.Request) {
-	redirectUri := url.QueryEscape("http://localhost:8080/callback/")
-	scopes := url.QueryEscape("playlist-read-private playlist-modify-private user-read-private")
-	http.Redirect(w, r, "https://accounts.spotify.com/authorize?client_id="+string(ClientId)+"&scope="+scopes+"&response_type=code&redirect_uri="+redirectUri, 302)
-
+	http.Redirect(w, r, authenticator.AuthCodeURL("state"), 302)
 }
 
 // This is the method that the user's browser session will be
@@ -92,50 +104,55 @@ func authCallback(w http.ResponseWriter, r *http.Request) {
 	var code string = r.URL.Query()["code"][0]
 	log.Printf("Code: %v\n", code)
 
-	tokenResponse, err := s.GetAccessToken(code, ClientId, ClientSecret, RedirectUri)
+	client, _, err := authenticator.Exchange(r.Context(), code)
 	if err != nil {
 		log.Println(err)
+		return
 	}
-	log.Println(tokenResponse.AccessToken)
 
-	userInfoResponse, err := s.GetUserInfo(tokenResponse.AccessToken)
+	userInfoResponse, err := s.GetUserInfo(client)
 	if err != nil {
 		log.Println(err)
+		return
 	}
 
 	username := userInfoResponse.Id
 	log.Printf("Username: %v\n", username)
+	clients[username] = client
 
-	http.Redirect(w, r, "/listplaylists/"+ string(username)+"/" +string(tokenResponse.AccessToken), http.StatusFound)
+	http.Redirect(w, r, "/listplaylists/"+string(username), http.StatusFound)
 }
-	
 
 // This method will response to requests starting with /listplaylists/ and it expects the URL path to include:
-// 		/listplaylists/{username}/{access_token}
+// 		/listplaylists/{username}
 // For each playlist retrieved, it will generate an <a href...> tag that links
 // back to this server with information about the playlist encoded in the URL path
 // information in this format:
-//   /tracks/{username}/{access_token}/{playlist_id}
+//   /tracks/{username}/{playlist_id}
 func listPlaylists(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(r.URL.Path, "/")
 	username := s.Username(parts[2])
-	accessToken := s.AccessToken(parts[3])
+	client := clients[username]
+	if client == nil {
+		http.Error(w, "unknown user - log in again via /login/", http.StatusUnauthorized)
+		return
+	}
 
-	playlistItems, err := s.GetUserPlaylists(accessToken, username)
+	playlistItems, err := s.GetUserPlaylists(client, username)
 	if err != nil {
 		log.Println(err)
 	}
 
 	for _, i := range playlistItems {
 		log.Printf(" [%v]:[%v]\n", i.Id, i.Name)
-		fmt.Fprintf(w, "<a href=\"/tracks/%v/%v/%v\">List tracks - %v</a> - \n", i.Owner.Id, accessToken, i.Id, i.Name)
-		fmt.Fprintf(w, "<a href=\"/duplicate/%v/%v/%v/%v\">Duplicate - %v</a><br/>\n", i.Owner.Id, username, accessToken, i.Id, i.Name)
+		fmt.Fprintf(w, "<a href=\"/tracks/%v/%v\">List tracks - %v</a> - \n", i.Owner.Id, i.Id, i.Name)
+		fmt.Fprintf(w, "<a href=\"/duplicate/%v/%v/%v\">Duplicate - %v</a><br/>\n", i.Owner.Id, username, i.Id, i.Name)
 	}
 }
 
 // This function handles calls to URLs starting with /tracks/ and it expects
 // that the playlist information is encoded into the URL in the following format:
-//    /tracks/{username}/{access_token}/{playlist_id}
+//    /tracks/{username}/{playlist_id}
 //
 // Using the information in that URL, it will retrieve the contents of the playlist
 // and list them on the page.
@@ -144,13 +161,17 @@ func showTracks(w http.ResponseWriter, r *http.Request) {
 	log.Printf("URL: %v\n", r.URL)
 	parts := strings.Split(r.URL.Path, "/")
 	username := s.Username(parts[2])
-	accessToken := s.AccessToken(parts[3])
-	playlistId := parts[4]
+	playlistId := parts[3]
+	client := clients[username]
+	if client == nil {
+		http.Error(w, "unknown user - log in again via /login/", http.StatusUnauthorized)
+		return
+	}
 	for n, p := range parts {
 		fmt.Fprintf(w, "Tracks path %v %v!<br/>", n, p)
 	}
 
-	tracks, err := s.GetTracksForPlaylist(accessToken, username, playlistId)
+	tracks, err := s.GetTracksForPlaylist(client, username, playlistId)
 	if err != nil {
 		log.Println(err)
 		return
@@ -166,7 +187,7 @@ func showTracks(w http.ResponseWriter, r *http.Request) {
 
 // This function handles calls to URLs starting with /duplicate/ and it
 // expects the playlist information is encoded in the URL as follows:
-//    /tracks/{playlist_owner_username}/{playlist_creator_username}/{access_token}/{playlist_id}
+//    /duplicate/{playlist_owner_username}/{playlist_creator_username}/{playlist_id}
 //
 // Using the information in that URL, it will retrieve the contents of the playlist
 // and create a new playlist named "Copy of $OLD_NAME" contianing the same set of tracks.
@@ -176,36 +197,38 @@ func duplicatePlaylist(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(r.URL.Path, "/")
 	playlistOwner := s.Username(parts[2])
 	playlistCreator := s.Username(parts[3])
-	accessToken := s.AccessToken(parts[4])
-	playlistId := parts[5]
+	playlistId := parts[4]
+	client := clients[playlistCreator]
+	if client == nil {
+		http.Error(w, "unknown user - log in again via /login/", http.StatusUnauthorized)
+		return
+	}
 	for n, p := range parts {
 		fmt.Fprintf(w, "Tracks path %v %v!<br/>", n, p)
 	}
 
 	// important to get information on the existing playlist before creating the copy
-	playlist, err := s.GetPlaylistInfo(accessToken, playlistOwner, playlistId)
+	playlist, err := s.GetPlaylistInfo(client, playlistOwner, playlistId)
 	if err != nil {
 		log.Println(err)
 		return
 	}
-	
-	tracks, err := s.GetTracksForPlaylist(accessToken, playlistOwner, playlistId)
-	
+
+	tracks, err := s.GetTracksForPlaylist(client, playlistOwner, playlistId)
+
 	fmt.Fprintf(w, "<p>Original: %v-%v </p><br/>\n", playlist.Id, playlist.Name)
 
-	
-	duplicatePlaylist, err := s.CreatePlaylist(accessToken,playlistCreator , "Copy of " + playlist.Name, false)
-	
+	duplicatePlaylist, err := s.CreatePlaylist(client, playlistCreator, "Copy of "+playlist.Name, false)
+
 	fmt.Fprintf(w, "<p>Copy: %v-%v </p><br/>\n", duplicatePlaylist.Id, duplicatePlaylist.Name)
-	
-	addTracksResponse, err := s.AddTracksToPlaylist(accessToken, playlistCreator , duplicatePlaylist, tracks )
+
+	addTracksResponse, err := s.AddTracksToPlaylist(client, playlistCreator, duplicatePlaylist, tracks)
 	if err != nil {
-		log.Printf("Error adding tracks to playlist %v\n", duplicatePlaylist.Id, err)
+		log.Printf("Error adding tracks to playlist %v: %v\n", duplicatePlaylist.Id, err)
 		return
 	}
 	fmt.Fprintf(w, "<p>Snapshot ID: %v </p><br/>\n", addTracksResponse.SnapshotId)
 
-
 }
 
 // Here you can configure the handler functions for each of the three request types.
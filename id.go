@@ -0,0 +1,54 @@
+package spotify_client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// A Type identifies the kind of Spotify catalog object an ID refers to.
+type Type string
+
+const (
+	TypeAlbum    Type = "album"
+	TypeArtist   Type = "artist"
+	TypePlaylist Type = "playlist"
+	TypeTrack    Type = "track"
+	TypeUser     Type = "user"
+)
+
+// An ID is the opaque, base-62 identifier Spotify assigns to catalog
+// objects: tracks, albums, artists, playlists, and users.
+type ID string
+
+// URI renders id as a Spotify URI of the given kind, e.g.
+// "spotify:track:6rqhFgbbKwnb9MLmUQDhG6".
+func (id ID) URI(kind Type) string {
+	return fmt.Sprintf("spotify:%v:%v", kind, id)
+}
+
+// ParseURI parses a Spotify URI ("spotify:track:6rqhFgbbKwnb9MLmUQDhG6") or
+// an open.spotify.com link - as pasted from the Spotify desktop app or web
+// player - and returns the object's Type and ID.
+func ParseURI(uri string) (Type, ID, error) {
+	if strings.HasPrefix(uri, "spotify:") {
+		parts := strings.Split(uri, ":")
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return "", "", fmt.Errorf("spotify_client: malformed URI: %q", uri)
+		}
+		return Type(parts[1]), ID(parts[2]), nil
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", fmt.Errorf("spotify_client: not a Spotify URI or link: %q", uri)
+	}
+	if !strings.HasSuffix(u.Hostname(), "open.spotify.com") {
+		return "", "", fmt.Errorf("spotify_client: not a Spotify URI or link: %q", uri)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("spotify_client: malformed Spotify link: %q", uri)
+	}
+	return Type(parts[0]), ID(parts[1]), nil
+}
@@ -0,0 +1,274 @@
+package spotify_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Default page sizes used by the iterators below. These match Spotify's
+// maximum limit for each endpoint, rather than the small page size the
+// original "collect everything" functions used, cutting the number of
+// requests needed to walk a large playlist or library by an order of
+// magnitude.
+const (
+	defaultPlaylistPageSize = 50
+	defaultTrackPageSize    = 100
+)
+
+// pagedUrl builds the initial URL for a paginated GET against base,
+// applying opts (or defaultLimit if opts is nil or has no Limit set).
+func pagedUrl(base string, opts *Options, defaultLimit int32) string {
+	limit := defaultLimit
+	if opts != nil && opts.Limit > 0 {
+		limit = opts.Limit
+	}
+	reqUrl := fmt.Sprintf("%v?limit=%v", base, limit)
+	if opts != nil {
+		if opts.Market != "" {
+			reqUrl += "&market=" + opts.Market
+		}
+		if opts.Offset > 0 {
+			reqUrl += fmt.Sprintf("&offset=%v", opts.Offset)
+		}
+	}
+	return reqUrl
+}
+
+// A PlaylistIterator lazily fetches a user's playlists one page at a time,
+// following the "next" link in Spotify's paging object, instead of
+// collecting every page into memory up front. Construct one with
+// NewPlaylistIterator.
+type PlaylistIterator struct {
+	client  *http.Client
+	nextUrl string
+	items   []Playlist
+	err     error
+}
+
+// NewPlaylistIterator returns a PlaylistIterator over username's playlists.
+func NewPlaylistIterator(client *http.Client, username Username, opts *Options) *PlaylistIterator {
+	base := fmt.Sprintf("https://api.spotify.com/v1/users/%v/playlists", username)
+	return &PlaylistIterator{client: client, nextUrl: pagedUrl(base, opts, defaultPlaylistPageSize)}
+}
+
+// Next advances the iterator and returns the next Playlist. It returns
+// false once the iterator is exhausted; callers should then check Err to
+// distinguish a clean end from a failed fetch.
+func (it *PlaylistIterator) Next(ctx context.Context) (Playlist, bool, error) {
+	for len(it.items) == 0 {
+		if it.err != nil || it.nextUrl == "" {
+			return Playlist{}, false, it.err
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return Playlist{}, false, err
+		}
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, true, nil
+}
+
+func (it *PlaylistIterator) fetch(ctx context.Context) error {
+	res := new(PlaylistResponse)
+	if err := doRequestCtx(ctx, it.client, "GET", it.nextUrl, nil, res); err != nil {
+		return err
+	}
+	it.items = res.Items
+	it.nextUrl = res.Next
+	return nil
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *PlaylistIterator) Err() error {
+	return it.err
+}
+
+// A TrackIterator lazily fetches the tracks of a playlist one page at a
+// time, following the "next" link in Spotify's paging object. Construct
+// one with NewTrackIterator.
+type TrackIterator struct {
+	client  *http.Client
+	nextUrl string
+	items   []Track
+	err     error
+}
+
+// NewTrackIterator returns a TrackIterator over the tracks of playlistId,
+// owned by owner.
+func NewTrackIterator(client *http.Client, owner Username, playlistId string, opts *Options) *TrackIterator {
+	base := fmt.Sprintf("https://api.spotify.com/v1/users/%v/playlists/%v/tracks", owner, playlistId)
+	return &TrackIterator{client: client, nextUrl: pagedUrl(base, opts, defaultTrackPageSize)}
+}
+
+// Next advances the iterator and returns the next Track. It returns false
+// once the iterator is exhausted; callers should then check Err to
+// distinguish a clean end from a failed fetch.
+func (it *TrackIterator) Next(ctx context.Context) (Track, bool, error) {
+	for len(it.items) == 0 {
+		if it.err != nil || it.nextUrl == "" {
+			return Track{}, false, it.err
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return Track{}, false, err
+		}
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, true, nil
+}
+
+func (it *TrackIterator) fetch(ctx context.Context) error {
+	res := new(TracklistResponse)
+	if err := doRequestCtx(ctx, it.client, "GET", it.nextUrl, nil, res); err != nil {
+		return err
+	}
+	it.items = make([]Track, len(res.Items))
+	for i, item := range res.Items {
+		it.items[i] = item.Track
+	}
+	it.nextUrl = res.Next
+	return nil
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *TrackIterator) Err() error {
+	return it.err
+}
+
+// A SavedTrack is an entry in a user's music library: a Track along with
+// the time the user saved it.
+type SavedTrack struct {
+	AddedAt string `json:"added_at"`
+	Track   Track  `json:"track"`
+}
+
+type savedTracksResponse struct {
+	Href     string       `json:"href"`
+	Items    []SavedTrack `json:"items"`
+	Limit    int32        `json:"limit"`
+	Offset   int32        `json:"offset"`
+	Next     string       `json:"next"`
+	Previous string       `json:"previous"`
+	Total    int32        `json:"total"`
+}
+
+// A SavedTrackIterator lazily fetches the current user's saved ("Liked
+// Songs") tracks one page at a time. Construct one with
+// Client.SavedTracks.
+type SavedTrackIterator struct {
+	http    *http.Client
+	nextUrl string
+	items   []SavedTrack
+	err     error
+}
+
+// SavedTracks returns a SavedTrackIterator over the current user's saved
+// tracks.
+func (c *Client) SavedTracks(opts *Options) *SavedTrackIterator {
+	base := fmt.Sprintf("%v/me/tracks", c.baseURL)
+	return &SavedTrackIterator{http: c.http, nextUrl: pagedUrl(base, opts, defaultTrackPageSize)}
+}
+
+// Next advances the iterator and returns the next SavedTrack. It returns
+// false once the iterator is exhausted; callers should then check Err to
+// distinguish a clean end from a failed fetch.
+func (it *SavedTrackIterator) Next(ctx context.Context) (SavedTrack, bool, error) {
+	for len(it.items) == 0 {
+		if it.err != nil || it.nextUrl == "" {
+			return SavedTrack{}, false, it.err
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return SavedTrack{}, false, err
+		}
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, true, nil
+}
+
+func (it *SavedTrackIterator) fetch(ctx context.Context) error {
+	res := new(savedTracksResponse)
+	if err := doRequestCtx(ctx, it.http, "GET", it.nextUrl, nil, res); err != nil {
+		return err
+	}
+	it.items = res.Items
+	it.nextUrl = res.Next
+	return nil
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *SavedTrackIterator) Err() error {
+	return it.err
+}
+
+// A TrackSearchIterator lazily pages through the track results of a search,
+// following the "next" link of the "tracks" paging object. Construct one
+// with Client.SearchTracks.
+type TrackSearchIterator struct {
+	http    *http.Client
+	nextUrl string
+	items   []Track
+	err     error
+}
+
+// SearchTracks returns a TrackSearchIterator over the tracks matching
+// query.
+func (c *Client) SearchTracks(query string, opts *Options) *TrackSearchIterator {
+	reqUrl := fmt.Sprintf("%v/search?q=%v&type=%v", c.baseURL, url.QueryEscape(query), SearchTypeTrack)
+	var limit int32 = defaultTrackPageSize
+	if opts != nil && opts.Limit > 0 {
+		limit = opts.Limit
+	}
+	reqUrl += fmt.Sprintf("&limit=%v", limit)
+	if opts != nil {
+		if opts.Market != "" {
+			reqUrl += "&market=" + opts.Market
+		}
+		if opts.Offset > 0 {
+			reqUrl += fmt.Sprintf("&offset=%v", opts.Offset)
+		}
+	}
+	return &TrackSearchIterator{http: c.http, nextUrl: reqUrl}
+}
+
+// Next advances the iterator and returns the next Track. It returns false
+// once the iterator is exhausted; callers should then check Err to
+// distinguish a clean end from a failed fetch.
+func (it *TrackSearchIterator) Next(ctx context.Context) (Track, bool, error) {
+	for len(it.items) == 0 {
+		if it.err != nil || it.nextUrl == "" {
+			return Track{}, false, it.err
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return Track{}, false, err
+		}
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, true, nil
+}
+
+func (it *TrackSearchIterator) fetch(ctx context.Context) error {
+	res := new(SearchResponse)
+	if err := doRequestCtx(ctx, it.http, "GET", it.nextUrl, nil, res); err != nil {
+		return err
+	}
+	if res.Tracks == nil {
+		it.nextUrl = ""
+		return nil
+	}
+	it.items = res.Tracks.Items
+	it.nextUrl = res.Tracks.Next
+	return nil
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *TrackSearchIterator) Err() error {
+	return it.err
+}
@@ -12,14 +12,11 @@
 package spotify_client
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"github.com/jmcvetta/napping"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"net/url"
 )
 
 func init() {
@@ -33,12 +30,6 @@ type ResponseUserAgent struct {
 
 // A Params is a map containing URL parameters.
 type Params map[string]string
-type TokenResponse struct {
-	AccessToken  AccessToken `json:"access_token"`
-	TokenType    string      `json:"token_type"`
-	ExpiresIn    int64       `json:"expires_in"`
-	RefreshToken string      `json:"refresh_token"`
-}
 
 type PlaylistResponse struct {
 	Href     string     `json:"href"`
@@ -91,24 +82,42 @@ type PlaylistTrack struct {
 }
 
 type Track struct {
-	Id      string   `json:"id"`
-	Href    string   `json:"href"`
-	Name    string   `json:"name"`
-	Album   Album    `json:"album"`
-	Artists []Artist `json:"artists"`
+	Id         string   `json:"id"`
+	Href       string   `json:"href"`
+	Name       string   `json:"name"`
+	Album      Album    `json:"album"`
+	Artists    []Artist `json:"artists"`
+	DurationMs int32    `json:"duration_ms"`
+	Popularity int32    `json:"popularity"`
+	Explicit   bool     `json:"explicit"`
 }
 
 type Artist struct {
-	Href string `json:"href"`
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Href       string   `json:"href"`
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Genres     []string `json:"genres"`
+	Popularity int32    `json:"popularity"`
+	Images     []Image  `json:"images"`
 }
 
 type Album struct {
-	AlbumType string `json:"album_type"`
-	Href      string `json:"href"`
-	Id        string `json:"id"`
-	Name      string `json:"name"`
+	AlbumType   string   `json:"album_type"`
+	Href        string   `json:"href"`
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	ReleaseDate string   `json:"release_date"`
+	TotalTracks int32    `json:"total_tracks"`
+	Images      []Image  `json:"images"`
+	Artists     []Artist `json:"artists"`
+}
+
+// An Image is a cover art or profile image at a specific resolution, as
+// returned for albums, artists, and playlists.
+type Image struct {
+	Url    string `json:"url"`
+	Height int32  `json:"height"`
+	Width  int32  `json:"width"`
 }
 
 type UserInfoResponse struct {
@@ -119,65 +128,23 @@ type UserInfoResponse struct {
 }
 
 // Type overrides to ensure various string-like values don't get mixed up.
-type AccessToken string
 type Username string
 type ClientId string
 type ClientSecret string
 type RedirectUri string
 
-// Given an access code returned by the spotify web server, along with the
-// Client ID and Client Secret for your spotify app (see: https://developer.spotify.com/my-applications/)
-// this method will retrieve an access token, returned as type TokenResponse
-func GetAccessToken(accessCode string, clientId ClientId, clientSecret ClientSecret, redirectUri RedirectUri) (*TokenResponse, error) {
+// Given an *http.Client obtained from an Authenticator (see auth.go), this
+// function will retrieve information about the authenticated user. This
+// information is used to retrieve their playlists later.
+func GetUserInfo(client *http.Client) (*UserInfoResponse, error) {
 
-	resp, err := http.PostForm("https://accounts.spotify.com/api/token",
-		url.Values{
-			"grant_type":    {"authorization_code"},
-			"code":          {accessCode},
-			"redirect_uri":  {string(redirectUri)},
-			"client_id":     {string(clientId)},
-			"client_secret": {string(clientSecret)},
-		})
-
-	if err != nil {
-		// handle error
-	}
-	defer resp.Body.Close()
-	log.Printf("Status code %v\n", resp.StatusCode)
-	body, err := ioutil.ReadAll(resp.Body)
-	if body != nil {
-		var tokenResponse = new(TokenResponse)
-		err := json.Unmarshal(body, &tokenResponse)
-		if err == nil {
-			log.Printf("JSON: %v+\n", tokenResponse)
-			log.Printf("Access Token: %v\n", tokenResponse.AccessToken)
-			return tokenResponse, nil
-		} else {
-			log.Println(err)
-			return nil, err
-		}
-	}
-
-	log.Println("Empty response body")
-	return nil, errors.New("Empty response body")
-
-}
-
-// Given an AccessToken returned by the GetAccessToken method, this
-// function will retrieve information about the authenticated user.
-// This information is used to retrieve their playlists later.
-func GetUserInfo(accessToken AccessToken) (*UserInfoResponse, error) {
-
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
+	s := napping.Session{Client: client}
 
 	res := ResponseUserAgent{}
 	url := "https://api.spotify.com/v1/me"
 
 	res = ResponseUserAgent{}
-	resp, err := s.Get(url, nil, &res, nil)
+	resp, err := doRequest(&s, &napping.Request{Method: "GET", Url: url, Result: &res})
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -192,71 +159,43 @@ func GetUserInfo(accessToken AccessToken) (*UserInfoResponse, error) {
 	return userInfoResponse, nil
 }
 
-// Given an AccessToken and a UserName (retrieved using the GetAccessToken and GetUserInfo functions),
-// this function will extract all of the user's playlists as a slice of Playlist objects.
-func GetUserPlaylists(accessToken AccessToken, username Username) ([]Playlist, error) {
-
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
-
-	res := ResponseUserAgent{}
-
-	offset := 0
-	limit := 5
-
+// Given an *http.Client obtained from an Authenticator and a UserName
+// (retrieved using the GetUserInfo function), this function will extract
+// all of the user's playlists as a slice of Playlist objects.
+//
+// This is a thin wrapper over NewPlaylistIterator for callers that would
+// rather hold the whole list in memory than page through it themselves.
+func GetUserPlaylists(client *http.Client, username Username) ([]Playlist, error) {
+	it := NewPlaylistIterator(client, username, nil)
 	playlistItems := make([]Playlist, 0, 1)
-	for done := false; done == false; {
-
-		res = ResponseUserAgent{}
-		url := fmt.Sprintf("https://api.spotify.com/v1/users/%v/playlists?limit=%v&offset=%v", username, limit, offset)
-
-		resp, err := s.Get(url, nil, &res, nil)
+	for {
+		item, ok, err := it.Next(context.Background())
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		log.Printf("Response URL: %v\n", resp.Url)
-		var playlistResponse = new(PlaylistResponse)
-
-		err = resp.Unmarshal(&playlistResponse)
-		if err != nil {
-			log.Println(err)
+		if !ok {
+			break
 		}
-		log.Printf("Items Length: %v\n", len(playlistResponse.Items))
-		log.Printf("Total items: %v\n", playlistResponse.Total)
-
-		for _, item := range playlistResponse.Items {
-			playlistItems = append(playlistItems, item)
-		}
-		if int32(len(playlistItems)) >= playlistResponse.Total {
-			done = true
-		} else {
-			offset += limit
-		}
-		log.Printf("Accumulated Items: %v\n", len(playlistItems))
+		playlistItems = append(playlistItems, item)
 	}
-
 	return playlistItems, nil
 }
 
 
-// Given an AccessToken, UserName (retrieved using the GetAccessToken and GetUserInfo functions),
-// and Playlist ID, this function will return a simplified Playlist object
-func GetPlaylistInfo(accessToken AccessToken, username Username, playlistId string) (Playlist, error) {
+// Given an *http.Client obtained from an Authenticator, UserName (retrieved
+// using the GetUserInfo function), and Playlist ID, this function will
+// return a simplified Playlist object
+func GetPlaylistInfo(client *http.Client, username Username, playlistId string) (Playlist, error) {
 
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
+	s := napping.Session{Client: client}
 
 	res := ResponseUserAgent{}
 
 	res = ResponseUserAgent{}
 	reqUrl := fmt.Sprintf("https://api.spotify.com/v1/users/%v/playlists/%v", username, playlistId)
 
-	resp, err := s.Get(reqUrl, nil, &res, nil)
+	resp, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: &res})
 	if err != nil {
 		log.Println(err)
 		return Playlist{}, err
@@ -272,14 +211,12 @@ func GetPlaylistInfo(accessToken AccessToken, username Username, playlistId stri
 	return *playlist, nil
 }
 
-// Given an AccessToken, UserName (retrieved using the GetAccessToken and GetUserInfo functions),
-// and new playlist name, this method will create a new playlist.
-func CreatePlaylist(accessToken AccessToken, username Username, playlistName string, playlistPublic bool) (Playlist, error) {
+// Given an *http.Client obtained from an Authenticator, UserName (retrieved
+// using the GetUserInfo function), and new playlist name, this method will
+// create a new playlist.
+func CreatePlaylist(client *http.Client, username Username, playlistName string, playlistPublic bool) (Playlist, error) {
 
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
+	s := napping.Session{Client: client}
 
 	res := ResponseUserAgent{}
 
@@ -291,7 +228,7 @@ func CreatePlaylist(accessToken AccessToken, username Username, playlistName str
 			Name: playlistName, 
 			Public: playlistPublic,
 			}
-	resp, err := s.Post(reqUrl, payload, &res, nil)
+	resp, err := doRequest(&s, &napping.Request{Method: "POST", Url: reqUrl, Payload: payload, Result: &res})
 	s.Log = false
 	if err != nil {
 		log.Println(err)
@@ -310,12 +247,9 @@ func CreatePlaylist(accessToken AccessToken, username Username, playlistName str
 }
 
 // Method will add tracks to an existing playlist
-func AddTracksToPlaylist(accessToken AccessToken, username Username, playlist Playlist, tracks []Track) (AddTrackToPlaylistResponse, error) {
-		
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
+func AddTracksToPlaylist(client *http.Client, username Username, playlist Playlist, tracks []Track) (AddTrackToPlaylistResponse, error) {
+
+	s := napping.Session{Client: client}
 
 	res := ResponseUserAgent{}
 	res = ResponseUserAgent{}
@@ -334,10 +268,10 @@ func AddTracksToPlaylist(accessToken AccessToken, username Username, playlist Pl
 		}
 		for _, t := range tracks[i:x] {
 			log.Printf("Adding track to playlist: %v-%v\n", t.Id, t.Name)
-			addTracksRequest.Uris = append(addTracksRequest.Uris,  "spotify:track:" + t.Id)
+			addTracksRequest.Uris = append(addTracksRequest.Uris, ID(t.Id).URI(TypeTrack))
 		}
 		
-		resp, err := s.Post(reqUrl, addTracksRequest, &res, nil)
+		resp, err := doRequest(&s, &napping.Request{Method: "POST", Url: reqUrl, Payload: addTracksRequest, Result: &res})
 		if err != nil {
 			log.Println(err)
 			return AddTrackToPlaylistResponse{}, err
@@ -359,49 +293,22 @@ func AddTracksToPlaylist(accessToken AccessToken, username Username, playlist Pl
 
 // For a given user and playlist, this method will return track listings for
 // each entry in the selected playlist as a slice of Track objects.
-func GetTracksForPlaylist(accessToken AccessToken, owner Username, playlistId string) ([]Track, error) {
-
-	s := napping.Session{}
-	header := http.Header{}
-	header.Add("Authorization", "Bearer "+string(accessToken))
-	s.Header = &header
-
-	res := ResponseUserAgent{}
-
-	offset := 0
-	limit := 5
-
+//
+// This is a thin wrapper over NewTrackIterator for callers that would
+// rather hold the whole list in memory than page through it themselves.
+func GetTracksForPlaylist(client *http.Client, owner Username, playlistId string) ([]Track, error) {
+	it := NewTrackIterator(client, owner, playlistId, nil)
 	tracks := make([]Track, 0, 1)
-	for done := false; done == false; {
-
-		res = ResponseUserAgent{}
-		url := fmt.Sprintf("https://api.spotify.com/v1/users/%v/playlists/%v/tracks?limit=%v&offset=%v", owner, playlistId, limit, offset)
-
-		resp, err := s.Get(url, nil, &res, nil)
+	for {
+		item, ok, err := it.Next(context.Background())
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		log.Printf("Response URL: %v\n", resp.Url)
-		log.Printf("Body: %v\n", resp.RawText())
-		var tracklistResponse = new(TracklistResponse)
-
-		err = resp.Unmarshal(&tracklistResponse)
-		if err != nil {
-			log.Println(err)
-		}
-		log.Printf("Items Length: %v\n", len(tracklistResponse.Items))
-		log.Printf("Total items: %v\n", tracklistResponse.Total)
-
-		for _, item := range tracklistResponse.Items {
-			tracks = append(tracks, item.Track)
-		}
-		if int32(len(tracks)) >= tracklistResponse.Total {
-			done = true
-		} else {
-			offset += limit
+		if !ok {
+			break
 		}
-		log.Printf("Accumulated Items: %v\n", len(tracks))
+		tracks = append(tracks, item)
 	}
 	return tracks, nil
 }
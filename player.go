@@ -0,0 +1,103 @@
+package spotify_client
+
+import (
+	"fmt"
+
+	"github.com/jmcvetta/napping"
+)
+
+// A Device is a Spotify Connect device available to the current user.
+type Device struct {
+	Id               string `json:"id"`
+	IsActive         bool   `json:"is_active"`
+	IsPrivateSession bool   `json:"is_private_session"`
+	IsRestricted     bool   `json:"is_restricted"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	VolumePercent    int32  `json:"volume_percent"`
+}
+
+type devicesEnvelope struct {
+	Devices []Device `json:"devices"`
+}
+
+// A CurrentlyPlayingResponse describes what, if anything, is currently
+// playing on the user's active device.
+type CurrentlyPlayingResponse struct {
+	Timestamp  int64 `json:"timestamp"`
+	ProgressMs int32 `json:"progress_ms"`
+	IsPlaying  bool  `json:"is_playing"`
+	Item       Track `json:"item"`
+}
+
+// CurrentlyPlaying returns information about the track currently playing on
+// the user's active device.
+func (c *Client) CurrentlyPlaying() (*CurrentlyPlayingResponse, error) {
+	reqUrl := fmt.Sprintf("%v/me/player/currently-playing", c.baseURL)
+	s := napping.Session{Client: c.http}
+	res := new(CurrentlyPlayingResponse)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PlayerDevices lists the Spotify Connect devices currently available to
+// the user.
+func (c *Client) PlayerDevices() ([]Device, error) {
+	reqUrl := fmt.Sprintf("%v/me/player/devices", c.baseURL)
+	s := napping.Session{Client: c.http}
+	res := new(devicesEnvelope)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res.Devices, nil
+}
+
+// Play resumes playback on the given device. If deviceId is empty, the
+// currently active device is used.
+func (c *Client) Play(deviceId string) error {
+	return c.playerCommand("PUT", "play", deviceId)
+}
+
+// Pause pauses playback on the given device. If deviceId is empty, the
+// currently active device is used.
+func (c *Client) Pause(deviceId string) error {
+	return c.playerCommand("PUT", "pause", deviceId)
+}
+
+// Next skips to the next track on the given device. If deviceId is empty,
+// the currently active device is used.
+func (c *Client) Next(deviceId string) error {
+	return c.playerCommand("POST", "next", deviceId)
+}
+
+// Previous skips to the previous track on the given device. If deviceId is
+// empty, the currently active device is used.
+func (c *Client) Previous(deviceId string) error {
+	return c.playerCommand("POST", "previous", deviceId)
+}
+
+// playerCommand issues one of the parameterless player transport commands
+// (play, pause, next, previous) to the given device.
+func (c *Client) playerCommand(method, action, deviceId string) error {
+	reqUrl := fmt.Sprintf("%v/me/player/%v", c.baseURL, action)
+	if deviceId != "" {
+		reqUrl += "?device_id=" + deviceId
+	}
+	s := napping.Session{Client: c.http}
+	_, err := doRequest(&s, &napping.Request{Method: method, Url: reqUrl})
+	return err
+}
+
+// AddToQueue appends uri to the playback queue on the given device. If
+// deviceId is empty, the currently active device is used.
+func (c *Client) AddToQueue(uri string, deviceId string) error {
+	reqUrl := fmt.Sprintf("%v/me/player/queue?uri=%v", c.baseURL, uri)
+	if deviceId != "" {
+		reqUrl += "&device_id=" + deviceId
+	}
+	s := napping.Session{Client: c.http}
+	_, err := doRequest(&s, &napping.Request{Method: "POST", Url: reqUrl})
+	return err
+}
@@ -0,0 +1,122 @@
+package spotify_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmcvetta/napping"
+)
+
+type followPlaylistRequest struct {
+	Public bool `json:"public"`
+}
+
+// FollowPlaylist adds the current user as a follower of playlistId, making
+// it appear in their library. public controls whether the playlist shows up
+// in the user's public profile.
+func (c *Client) FollowPlaylist(playlistId string, public bool) error {
+	reqUrl := fmt.Sprintf("%v/playlists/%v/followers", c.baseURL, playlistId)
+	s := napping.Session{Client: c.http}
+	res := ResponseUserAgent{}
+	_, err := doRequest(&s, &napping.Request{Method: "PUT", Url: reqUrl, Payload: &followPlaylistRequest{Public: public}, Result: &res})
+	return err
+}
+
+// snapshotResponse is returned by the playlist-mutation endpoints that hand
+// back a new snapshot_id.
+type snapshotResponse struct {
+	SnapshotId string `json:"snapshot_id"`
+}
+
+type removeTracksRequest struct {
+	Tracks     []trackUri `json:"tracks"`
+	SnapshotId string     `json:"snapshot_id,omitempty"`
+}
+
+type trackUri struct {
+	Uri string `json:"uri"`
+}
+
+// RemoveTracksFromPlaylist removes every occurrence of the given track URIs
+// from playlistId. If snapshotId is non-empty, the removal is rejected
+// unless the playlist is still at that snapshot, giving callers a way to
+// implement optimistic concurrency against concurrent edits. It returns
+// the playlist's new snapshot_id.
+func (c *Client) RemoveTracksFromPlaylist(ctx context.Context, playlistId string, uris []string, snapshotId string) (string, error) {
+	reqUrl := fmt.Sprintf("%v/playlists/%v/tracks", c.baseURL, playlistId)
+	req := removeTracksRequest{SnapshotId: snapshotId}
+	for _, uri := range uris {
+		req.Tracks = append(req.Tracks, trackUri{Uri: uri})
+	}
+	res := new(snapshotResponse)
+	if err := doRequestCtx(ctx, c.http, "DELETE", reqUrl, &req, res); err != nil {
+		return "", err
+	}
+	return res.SnapshotId, nil
+}
+
+type reorderTracksRequest struct {
+	RangeStart   int    `json:"range_start"`
+	InsertBefore int    `json:"insert_before"`
+	RangeLength  int    `json:"range_length,omitempty"`
+	SnapshotId   string `json:"snapshot_id,omitempty"`
+}
+
+// ReorderPlaylistTracks moves the rangeLength tracks starting at rangeStart
+// to sit before the track currently at insertBefore. If snapshotId is
+// non-empty, the reorder is rejected unless the playlist is still at that
+// snapshot. It returns the playlist's new snapshot_id.
+func (c *Client) ReorderPlaylistTracks(ctx context.Context, playlistId string, rangeStart, insertBefore, rangeLength int, snapshotId string) (string, error) {
+	reqUrl := fmt.Sprintf("%v/playlists/%v/tracks", c.baseURL, playlistId)
+	req := reorderTracksRequest{
+		RangeStart:   rangeStart,
+		InsertBefore: insertBefore,
+		RangeLength:  rangeLength,
+		SnapshotId:   snapshotId,
+	}
+	res := new(snapshotResponse)
+	if err := doRequestCtx(ctx, c.http, "PUT", reqUrl, &req, res); err != nil {
+		return "", err
+	}
+	return res.SnapshotId, nil
+}
+
+type replaceTracksRequest struct {
+	Uris []string `json:"uris"`
+}
+
+// ReplacePlaylistTracks replaces every track in playlistId with uris,
+// returning the playlist's new snapshot_id.
+func (c *Client) ReplacePlaylistTracks(ctx context.Context, playlistId string, uris []string) (string, error) {
+	reqUrl := fmt.Sprintf("%v/playlists/%v/tracks", c.baseURL, playlistId)
+	req := replaceTracksRequest{Uris: uris}
+	res := new(snapshotResponse)
+	if err := doRequestCtx(ctx, c.http, "PUT", reqUrl, &req, res); err != nil {
+		return "", err
+	}
+	return res.SnapshotId, nil
+}
+
+type changePlaylistDetailsRequest struct {
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Public        *bool  `json:"public,omitempty"`
+	Collaborative *bool  `json:"collaborative,omitempty"`
+}
+
+// ChangePlaylistDetails updates playlistId's name, description, and
+// visibility. name and description are left unchanged if empty; public and
+// collaborative are left unchanged if nil. Unlike the other mutating
+// playlist calls, Spotify does not version this endpoint with a
+// snapshot_id.
+func (c *Client) ChangePlaylistDetails(ctx context.Context, playlistId string, name, description string, public, collaborative *bool) error {
+	reqUrl := fmt.Sprintf("%v/playlists/%v", c.baseURL, playlistId)
+	req := changePlaylistDetailsRequest{
+		Name:          name,
+		Description:   description,
+		Public:        public,
+		Collaborative: collaborative,
+	}
+	var res ResponseUserAgent
+	return doRequestCtx(ctx, c.http, "PUT", reqUrl, &req, &res)
+}
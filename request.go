@@ -0,0 +1,157 @@
+package spotify_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmcvetta/napping"
+)
+
+// maxRetries bounds how many times doRequest will retry a request after
+// receiving a 429 Too Many Requests response.
+const maxRetries = 5
+
+// maxRetryAfter caps how long doRequest will sleep for between retries,
+// even if Spotify's Retry-After header asks for longer.
+const maxRetryAfter = 60 * time.Second
+
+// doRequest sends r using s. On a 429 Too Many Requests response it sleeps
+// for the duration given in the Retry-After header (capped at
+// maxRetryAfter) and retries, up to maxRetries times. Any other response
+// with a status >= 400 is decoded as Spotify's error envelope and returned
+// as an *APIError, so callers can distinguish API errors from transport
+// failures.
+//
+// Every function in this package that talks to the Spotify Web API routes
+// its request through doRequest or its context-aware twin doRequestCtx,
+// which keeps retry and error-handling behavior consistent across the whole
+// library.
+func doRequest(s *napping.Session, r *napping.Request) (*napping.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := s.Send(r)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Status() == http.StatusTooManyRequests && attempt < maxRetries {
+			wait := retryAfter(resp)
+			log.Printf("Rate limited by Spotify, retrying in %v\n", wait)
+			time.Sleep(wait)
+			continue
+		}
+		if resp.Status() >= 400 {
+			envelope := apiErrorEnvelope{}
+			if uerr := resp.Unmarshal(&envelope); uerr == nil && envelope.Err != nil {
+				return resp, envelope.Err
+			}
+			return resp, &APIError{Status: resp.Status(), Message: resp.RawText()}
+		}
+		return resp, nil
+	}
+}
+
+// retryAfter parses the Retry-After header (in seconds) from resp, capped
+// at maxRetryAfter and defaulting to one second if the header is missing or
+// malformed.
+func retryAfter(resp *napping.Response) time.Duration {
+	return retryAfterHeader(resp.HttpResponse().Header)
+}
+
+// retryAfterHeader is the header-only half of retryAfter, shared with
+// doRequestCtx, which has no *napping.Response to read from.
+func retryAfterHeader(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		seconds = 1
+	}
+	wait := time.Duration(seconds) * time.Second
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait
+}
+
+// doRequestCtx behaves like doRequest - retrying on 429 with the server's
+// Retry-After delay, up to maxRetries, and decoding responses with a status
+// >= 400 as an *APIError - but sends the request with net/http directly instead of
+// napping, since napping builds its *http.Request internally and has no way
+// to attach a context. That lets doRequestCtx honor ctx: it's checked before
+// every attempt and while sleeping between retries, so a canceled or
+// deadline-exceeded ctx stops the request instead of running to completion.
+//
+// payload, if non-nil, is JSON-encoded as the request body. result, if
+// non-nil, is JSON-decoded from a successful response body.
+func doRequestCtx(ctx context.Context, client *http.Client, method, url string, payload, result interface{}) error {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		status, body, header, err := sendCtx(ctx, client, method, url, payload, result)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusTooManyRequests && attempt < maxRetries {
+			wait := retryAfterHeader(header)
+			log.Printf("Rate limited by Spotify, retrying in %v\n", wait)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+		if status >= 400 {
+			envelope := apiErrorEnvelope{}
+			if uerr := json.Unmarshal(body, &envelope); uerr == nil && envelope.Err != nil {
+				return envelope.Err
+			}
+			return &APIError{Status: status, Message: strings.TrimSpace(string(body))}
+		}
+		return nil
+	}
+}
+
+// sendCtx builds and sends a single HTTP request using client, JSON-encoding
+// payload as the body if it's non-nil. On a response whose body is
+// non-empty, it is always returned in body; it's additionally JSON-decoded
+// into result if the response succeeded (status < 300) and result is
+// non-nil.
+func sendCtx(ctx context.Context, client *http.Client, method, url string, payload, result interface{}) (status int, body []byte, header http.Header, err error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		b, merr := json.Marshal(payload)
+		if merr != nil {
+			return 0, nil, nil, merr
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if resp.StatusCode < 300 && result != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, result); err != nil {
+			return resp.StatusCode, body, resp.Header, err
+		}
+	}
+	return resp.StatusCode, body, resp.Header, nil
+}
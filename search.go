@@ -0,0 +1,107 @@
+package spotify_client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jmcvetta/napping"
+)
+
+// A SearchType identifies a kind of catalog object that can be requested
+// from Search.
+type SearchType string
+
+const (
+	SearchTypeAlbum    SearchType = "album"
+	SearchTypeArtist   SearchType = "artist"
+	SearchTypePlaylist SearchType = "playlist"
+	SearchTypeTrack    SearchType = "track"
+)
+
+// A SearchResponse holds the paged results for each SearchType requested.
+// Only the fields corresponding to the requested types are populated.
+type SearchResponse struct {
+	Tracks    *TracksPage       `json:"tracks"`
+	Albums    *AlbumsPage       `json:"albums"`
+	Artists   *ArtistsPage      `json:"artists"`
+	Playlists *PlaylistResponse `json:"playlists"`
+}
+
+type TracksPage struct {
+	Href     string  `json:"href"`
+	Items    []Track `json:"items"`
+	Limit    int32   `json:"limit"`
+	Offset   int32   `json:"offset"`
+	Next     string  `json:"next"`
+	Previous string  `json:"previous"`
+	Total    int32   `json:"total"`
+}
+
+type AlbumsPage struct {
+	Href     string  `json:"href"`
+	Items    []Album `json:"items"`
+	Limit    int32   `json:"limit"`
+	Offset   int32   `json:"offset"`
+	Next     string  `json:"next"`
+	Previous string  `json:"previous"`
+	Total    int32   `json:"total"`
+}
+
+type ArtistsPage struct {
+	Href     string   `json:"href"`
+	Items    []Artist `json:"items"`
+	Limit    int32    `json:"limit"`
+	Offset   int32    `json:"offset"`
+	Next     string   `json:"next"`
+	Previous string   `json:"previous"`
+	Total    int32    `json:"total"`
+}
+
+// Search queries the Spotify catalog for the given free-text query,
+// restricted to the requested types (tracks, albums, artists, playlists).
+func (c *Client) Search(query string, types []SearchType, opts *Options) (*SearchResponse, error) {
+	typeStrings := make([]string, len(types))
+	for i, t := range types {
+		typeStrings[i] = string(t)
+	}
+	reqUrl := fmt.Sprintf("%v/search?q=%v&type=%v", c.baseURL, url.QueryEscape(query), strings.Join(typeStrings, ","))
+	reqUrl += optionsQuery(opts)
+
+	s := napping.Session{Client: c.http}
+	res := new(SearchResponse)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// optionsQuery renders opts as a query string suffix (e.g. "&market=US"),
+// or the empty string if opts is nil.
+func optionsQuery(opts *Options) string {
+	if opts == nil {
+		return ""
+	}
+	q := ""
+	if opts.Market != "" {
+		q += fmt.Sprintf("&market=%v", opts.Market)
+	}
+	if opts.Limit > 0 {
+		q += fmt.Sprintf("&limit=%v", opts.Limit)
+	}
+	if opts.Offset > 0 {
+		q += fmt.Sprintf("&offset=%v", opts.Offset)
+	}
+	return q
+}
+
+// appendOptions appends opts to reqUrl (which must not already have a query
+// string) as a "?"-prefixed query, or returns reqUrl unchanged if opts is
+// nil or empty.
+func appendOptions(reqUrl string, opts *Options) string {
+	q := optionsQuery(opts)
+	if q == "" {
+		return reqUrl
+	}
+	return reqUrl + "?" + strings.TrimPrefix(q, "&")
+}
@@ -0,0 +1,124 @@
+package spotify_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmcvetta/napping"
+)
+
+type tracksEnvelope struct {
+	Tracks []Track `json:"tracks"`
+}
+
+// GetTrack retrieves catalog information for a single track.
+func (c *Client) GetTrack(id string, opts *Options) (*Track, error) {
+	reqUrl := appendOptions(fmt.Sprintf("%v/tracks/%v", c.baseURL, id), opts)
+	s := napping.Session{Client: c.http}
+	track := new(Track)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: track}); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
+
+// GetTracks retrieves catalog information for multiple tracks in a single
+// request. Spotify allows up to 50 ids per call.
+func (c *Client) GetTracks(ids []string, opts *Options) ([]Track, error) {
+	reqUrl := fmt.Sprintf("%v/tracks?ids=%v%v", c.baseURL, strings.Join(ids, ","), optionsQuery(opts))
+	s := napping.Session{Client: c.http}
+	res := new(tracksEnvelope)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res.Tracks, nil
+}
+
+// AudioFeatures describes the audio characteristics Spotify's analysis
+// pipeline derives for a track (tempo, key, danceability, and so on).
+type AudioFeatures struct {
+	Id               string  `json:"id"`
+	Danceability     float32 `json:"danceability"`
+	Energy           float32 `json:"energy"`
+	Key              int32   `json:"key"`
+	Loudness         float32 `json:"loudness"`
+	Mode             int32   `json:"mode"`
+	Speechiness      float32 `json:"speechiness"`
+	Acousticness     float32 `json:"acousticness"`
+	Instrumentalness float32 `json:"instrumentalness"`
+	Liveness         float32 `json:"liveness"`
+	Valence          float32 `json:"valence"`
+	Tempo            float32 `json:"tempo"`
+	DurationMs       int32   `json:"duration_ms"`
+	TimeSignature    int32   `json:"time_signature"`
+}
+
+// GetAudioFeatures retrieves the audio features Spotify has computed for a
+// single track.
+func (c *Client) GetAudioFeatures(trackId string) (*AudioFeatures, error) {
+	reqUrl := fmt.Sprintf("%v/audio-features/%v", c.baseURL, trackId)
+	s := napping.Session{Client: c.http}
+	features := new(AudioFeatures)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: features}); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// RecommendationSeed describes one of the seeds (artist, genre, or track)
+// used to generate a set of recommendations, along with how many candidate
+// tracks it contributed.
+type RecommendationSeed struct {
+	Id                 string `json:"id"`
+	Type               string `json:"type"`
+	InitialPoolSize    int32  `json:"initialPoolSize"`
+	AfterFilteringSize int32  `json:"afterFilteringSize"`
+	AfterRelinkingSize int32  `json:"afterRelinkingSize"`
+}
+
+// A RecommendationsResponse holds the tracks Spotify recommends for a set
+// of seeds, along with the seeds actually used to generate them.
+type RecommendationsResponse struct {
+	Seeds  []RecommendationSeed `json:"seeds"`
+	Tracks []Track              `json:"tracks"`
+}
+
+// RecommendationOptions configures GetRecommendations. At least one seed
+// field must be populated, and the total number of seeds (artists + genres
+// + tracks) may not exceed 5.
+type RecommendationOptions struct {
+	SeedArtists []string
+	SeedGenres  []string
+	SeedTracks  []string
+	Market      string
+	Limit       int32
+}
+
+// GetRecommendations returns a set of tracks similar to the given seed
+// artists, genres, and/or tracks.
+func (c *Client) GetRecommendations(opts RecommendationOptions) (*RecommendationsResponse, error) {
+	params := []string{}
+	if len(opts.SeedArtists) > 0 {
+		params = append(params, "seed_artists="+strings.Join(opts.SeedArtists, ","))
+	}
+	if len(opts.SeedGenres) > 0 {
+		params = append(params, "seed_genres="+strings.Join(opts.SeedGenres, ","))
+	}
+	if len(opts.SeedTracks) > 0 {
+		params = append(params, "seed_tracks="+strings.Join(opts.SeedTracks, ","))
+	}
+	if opts.Market != "" {
+		params = append(params, "market="+opts.Market)
+	}
+	if opts.Limit > 0 {
+		params = append(params, fmt.Sprintf("limit=%v", opts.Limit))
+	}
+	reqUrl := fmt.Sprintf("%v/recommendations?%v", c.baseURL, strings.Join(params, "&"))
+
+	s := napping.Session{Client: c.http}
+	res := new(RecommendationsResponse)
+	if _, err := doRequest(&s, &napping.Request{Method: "GET", Url: reqUrl, Result: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}